@@ -2,9 +2,15 @@ package elasticsearch
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -16,6 +22,22 @@ import (
 	"github.com/olivere/elastic/v7"
 )
 
+const bulkProcessorName = "concourse-build-events"
+
+// writeAliasName and readAliasName are the logical aliases that the store
+// writes to and reads from. Rollover (triggered by ILM or an explicit
+// Rollover call) atomically repoints writeAliasName at a new concrete index
+// without any code change or restart.
+const (
+	writeAliasName = "concourse-build-events-write"
+	readAliasName  = "concourse-build-events-read"
+)
+
+// ErrStoreUnavailable is returned by Put, Get, and Delete when the
+// Elasticsearch cluster is known to be down, so that callers fail fast
+// instead of blocking on a full HTTP timeout.
+var ErrStoreUnavailable = errors.New("elasticsearch store is unavailable")
+
 type eventDoc struct {
 	BuildID      int              `json:"build_id"`
 	BuildName    string           `json:"build_name"`
@@ -31,9 +53,20 @@ type eventDoc struct {
 	Tiebreak     int64            `json:"tiebreak"`
 }
 
+// defaultPitKeepAlive is used when a Key has no explicit KeepAlive.
+const defaultPitKeepAlive = time.Minute
+
 type Key struct {
 	TimeMillis int64 `json:"time"`
 	Tiebreak   int64 `json:"tiebreak"`
+
+	// PitID and KeepAlive identify a server-side Point-in-Time reader opened
+	// by Get, used to keep a long-running event stream stable across
+	// refreshes and rollovers. PitID is empty on clusters that don't
+	// support PIT (pre-7.10), in which case Get falls back to searching the
+	// read alias directly and KeepAlive has no effect.
+	PitID     string        `json:"pit_id,omitempty"`
+	KeepAlive time.Duration `json:"keep_alive,omitempty"`
 }
 
 func (k Key) Marshal() ([]byte, error) {
@@ -58,14 +91,71 @@ func (k Key) GreaterThan(o db.EventKey) bool {
 }
 
 type Store struct {
-	logger lager.Logger
-	client *elastic.Client
+	logger        lager.Logger
+	client        *elastic.Client
+	bulkProcessor *elastic.BulkProcessor
+
+	URL  string   `long:"url" description:"URL of Elasticsearch cluster."`
+	URLs []string `long:"urls" description:"URLs of additional Elasticsearch seed nodes."`
 
-	URL string `long:"url" description:"URL of Elasticsearch cluster."`
+	Username string `long:"username" description:"Username for basic auth against the Elasticsearch cluster."`
+	Password string `long:"password" description:"Password for basic auth against the Elasticsearch cluster."`
+	APIKey   string `long:"api-key" description:"API key for authenticating against the Elasticsearch cluster."`
+
+	CACert             string `long:"ca-cert" description:"Path to a PEM-encoded CA certificate used to verify the Elasticsearch cluster."`
+	ClientCert         string `long:"client-cert" description:"Path to a PEM-encoded client certificate for mutual TLS against the Elasticsearch cluster."`
+	ClientKey          string `long:"client-key" description:"Path to a PEM-encoded client key for mutual TLS against the Elasticsearch cluster."`
+	InsecureSkipVerify bool   `long:"insecure-skip-verify" description:"Skip verification of the Elasticsearch cluster's TLS certificate."`
+
+	Sniff               bool          `long:"sniff" description:"Enable sniffing to discover all nodes in the Elasticsearch cluster."`
+	HealthcheckInterval time.Duration `long:"healthcheck-interval" default:"60s" description:"Interval at which the Elasticsearch client healthchecks the cluster."`
+	GzipEnabled         bool          `long:"gzip" description:"Compress requests to the Elasticsearch cluster with gzip."`
+
+	BulkActions       int           `long:"bulk-actions" default:"1000" description:"Number of bulk actions to batch before flushing to Elasticsearch."`
+	BulkSize          int           `long:"bulk-size" default:"5242880" description:"Number of bytes to batch before flushing to Elasticsearch."`
+	BulkFlushInterval time.Duration `long:"bulk-flush-interval" default:"1s" description:"Interval at which batched bulk actions are flushed to Elasticsearch."`
+	BulkWorkers       int           `long:"bulk-workers" default:"1" description:"Number of concurrent workers flushing bulk requests to Elasticsearch."`
+
+	PingInterval time.Duration `long:"ping-interval" default:"10s" description:"Interval at which the cluster is probed for availability."`
+
+	available    atomic.Bool
+	pitSupported atomic.Bool
+	stopPing     chan struct{}
+
+	openPitsMu sync.Mutex
+	openPits   map[int]pitEntry
+
+	pendingMu sync.Mutex
+	pending   map[elastic.BulkableRequest]chan error
 
 	counter int64
 }
 
+// pitEntry tracks a PIT opened on behalf of a build's event stream, along
+// with when a Get last used it so sweepStalePits can reclaim it if the stream was
+// abandoned (crash, restart, a caller that never calls Finalize) instead of
+// leaking it for the life of the process.
+type pitEntry struct {
+	id     string
+	opened time.Time
+}
+
+// pitIdleTTL bounds how long an unreleased PIT is kept open. It's set well
+// above any realistic KeepAlive so it never races a live stream's renewal;
+// it only reclaims PITs whose build was abandoned without a Finalize call.
+const pitIdleTTL = 30 * time.Minute
+
+// lagerLogger adapts a lager.Logger to the elastic.Logger interface so that
+// client activity (tracing, info, and error logs) shows up in Concourse logs.
+type lagerLogger struct {
+	logger lager.Logger
+	level  string
+}
+
+func (l lagerLogger) Printf(format string, v ...interface{}) {
+	l.logger.Debug("elasticsearch-client-"+l.level, lager.Data{"message": fmt.Sprintf(format, v...)})
+}
+
 func (e *Store) IsConfigured() bool {
 	return e.URL != ""
 }
@@ -73,14 +163,44 @@ func (e *Store) IsConfigured() bool {
 func (e *Store) Setup(ctx context.Context) error {
 	e.logger = lagerctx.FromContext(ctx)
 
-	e.logger.Debug("setup-event-store", lager.Data{"url": e.URL})
-	var err error
-	e.client, err = elastic.NewClient(
-		elastic.SetURL(e.URL),
+	urls := append([]string{e.URL}, e.URLs...)
+	e.logger.Debug("setup-event-store", lager.Data{"urls": urls})
+
+	tlsConfig, err := e.tlsConfig()
+	if err != nil {
+		e.logger.Error("build-tls-config-failed", err)
+		return fmt.Errorf("build tls config: %w", err)
+	}
+
+	// Clone the default transport rather than starting from a bare
+	// &http.Transport{}, so proxy env vars (HTTP_PROXY/HTTPS_PROXY) and the
+	// default dial/idle-conn timeouts still apply; only TLSClientConfig
+	// differs from the default.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	options := []elastic.ClientOptionFunc{
+		elastic.SetURL(urls...),
 		elastic.SetHealthcheckTimeoutStartup(1 * time.Minute),
-	)
+		elastic.SetHealthcheckInterval(e.HealthcheckInterval),
+		elastic.SetSniff(e.Sniff),
+		elastic.SetGzip(e.GzipEnabled),
+		elastic.SetHttpClient(&http.Client{Transport: transport}),
+		elastic.SetTraceLog(lagerLogger{logger: e.logger, level: "trace"}),
+		elastic.SetInfoLog(lagerLogger{logger: e.logger, level: "info"}),
+		elastic.SetErrorLog(lagerLogger{logger: e.logger, level: "error"}),
+	}
+	if e.APIKey != "" {
+		options = append(options, elastic.SetHeaders(http.Header{
+			"Authorization": []string{"ApiKey " + e.APIKey},
+		}))
+	} else if e.Username != "" || e.Password != "" {
+		options = append(options, elastic.SetBasicAuth(e.Username, e.Password))
+	}
+
+	e.client, err = elastic.NewClient(options...)
 	if err != nil {
-		e.logger.Error("connect-to-cluster-failed", err, lager.Data{"url": e.URL})
+		e.logger.Error("connect-to-cluster-failed", err, lager.Data{"urls": urls})
 		return fmt.Errorf("connect to cluster: %w", err)
 	}
 
@@ -107,10 +227,251 @@ func (e *Store) Setup(ctx context.Context) error {
 		return fmt.Errorf("create initial index: %w", err)
 	}
 
+	err = e.ensureAlias(ctx, initialIndexName, writeAliasName, true)
+	if err != nil {
+		e.logger.Error("ensure-write-alias-failed", err, lager.Data{"index": initialIndexName, "alias": writeAliasName})
+		return fmt.Errorf("ensure write alias: %w", err)
+	}
+
+	err = e.ensureAlias(ctx, initialIndexName, readAliasName, false)
+	if err != nil {
+		e.logger.Error("ensure-read-alias-failed", err, lager.Data{"index": initialIndexName, "alias": readAliasName})
+		return fmt.Errorf("ensure read alias: %w", err)
+	}
+
+	e.bulkProcessor, err = e.client.BulkProcessor().
+		Name(bulkProcessorName).
+		Workers(e.bulkWorkers()).
+		BulkActions(e.bulkActions()).
+		BulkSize(e.bulkSize()).
+		FlushInterval(e.bulkFlushInterval()).
+		Backoff(elastic.NewExponentialBackoff(100*time.Millisecond, 10*time.Second)).
+		Before(e.beforeBulk).
+		After(e.afterBulk).
+		Do(ctx)
+	if err != nil {
+		e.logger.Error("start-bulk-processor-failed", err)
+		return fmt.Errorf("start bulk processor: %w", err)
+	}
+
+	version, err := e.client.ElasticsearchVersion(urls[0])
+	if err != nil {
+		e.logger.Error("probe-cluster-version-failed", err, lager.Data{"url": urls[0]})
+		return fmt.Errorf("probe cluster version: %w", err)
+	}
+	e.pitSupported.Store(supportsPIT(version))
+
+	e.available.Store(true)
+	e.stopPing = make(chan struct{})
+	go e.pingLoop(urls[0], e.stopPing)
+
 	return nil
 }
 
+// supportsPIT reports whether the given Elasticsearch version supports
+// Point-in-Time readers, which were introduced in 7.10.
+func supportsPIT(version string) bool {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &minor); err != nil {
+		return false
+	}
+	return major > 7 || (major == 7 && minor >= 10)
+}
+
+// IsAvailable reports whether the Elasticsearch cluster was reachable as of
+// the most recent ping or request. Callers (e.g. ATC build-event streaming)
+// can use this to degrade gracefully instead of blocking on a dead cluster.
+func (e *Store) IsAvailable() bool {
+	return e.available.Load()
+}
+
+func (e *Store) pingLoop(url string, stop <-chan struct{}) {
+	interval := e.PingInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			_, _, err := e.client.Ping(url).Do(ctx)
+			if err != nil {
+				e.logger.Error("ping-cluster-failed", err, lager.Data{"url": url})
+				e.available.Store(false)
+			} else {
+				e.available.Store(true)
+			}
+			e.sweepStalePits(ctx)
+			cancel()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Stop tears down the background availability checker. It is safe to call
+// multiple times.
+func (e *Store) Stop() {
+	if e.stopPing != nil {
+		close(e.stopPing)
+		e.stopPing = nil
+	}
+}
+
+// markUnavailableOnConnError flips the available flag only for genuine
+// transport/connection failures. A caller-cancelled or timed-out context
+// (routine when a build-log stream client disconnects) isn't a cluster
+// outage, and an *elastic.Error means the cluster answered, so neither
+// should take every other build's requests down with it.
+func (e *Store) markUnavailableOnConnError(err error) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	var urlErr *url.Error
+	if !elastic.IsConnErr(err) && !errors.As(err, &urlErr) {
+		return
+	}
+	e.available.Store(false)
+}
+
+func (e *Store) bulkActions() int {
+	if e.BulkActions > 0 {
+		return e.BulkActions
+	}
+	return 1000
+}
+
+func (e *Store) bulkSize() int {
+	if e.BulkSize > 0 {
+		return e.BulkSize
+	}
+	return 5 * 1024 * 1024
+}
+
+func (e *Store) bulkFlushInterval() time.Duration {
+	if e.BulkFlushInterval > 0 {
+		return e.BulkFlushInterval
+	}
+	return time.Second
+}
+
+func (e *Store) bulkWorkers() int {
+	if e.BulkWorkers > 0 {
+		return e.BulkWorkers
+	}
+	return 1
+}
+
+func (e *Store) beforeBulk(executionId int64, requests []elastic.BulkableRequest) {
+	e.logger.Debug("bulk-before", lager.Data{"execution_id": executionId, "requests": len(requests)})
+}
+
+func (e *Store) afterBulk(executionId int64, requests []elastic.BulkableRequest, response *elastic.BulkResponse, err error) {
+	if err != nil {
+		e.logger.Error("bulk-after-failed", err, lager.Data{"execution_id": executionId, "requests": len(requests)})
+		e.markUnavailableOnConnError(err)
+		e.resolvePending(requests, nil, err)
+		return
+	}
+	if response != nil && response.Errors {
+		for _, failed := range response.Failed() {
+			e.logger.Error("bulk-item-failed", fmt.Errorf("%s: %s", failed.Error.Type, failed.Error.Reason), lager.Data{
+				"execution_id": executionId,
+				"index":        failed.Index,
+				"id":           failed.Id,
+				"status":       failed.Status,
+			})
+		}
+	}
+	e.resolvePending(requests, response, nil)
+}
+
+// registerPending records the completion channel for a bulk request enqueued
+// by Put, so resolvePending can signal it once the bulk processor actually
+// attempts the write.
+func (e *Store) registerPending(req elastic.BulkableRequest, done chan error) {
+	e.pendingMu.Lock()
+	defer e.pendingMu.Unlock()
+	if e.pending == nil {
+		e.pending = make(map[elastic.BulkableRequest]chan error)
+	}
+	e.pending[req] = done
+}
+
+// resolvePending signals the completion channel for every request in this
+// batch, so Put callers waiting on them learn whether their event was
+// actually indexed rather than assuming success once it's merely enqueued.
+// batchErr is set when the whole batch failed to execute (e.g. a connection
+// error); otherwise each request is resolved against its own response item.
+func (e *Store) resolvePending(requests []elastic.BulkableRequest, response *elastic.BulkResponse, batchErr error) {
+	e.pendingMu.Lock()
+	defer e.pendingMu.Unlock()
+	for i, req := range requests {
+		done, ok := e.pending[req]
+		if !ok {
+			continue
+		}
+		delete(e.pending, req)
+
+		if batchErr != nil {
+			done <- batchErr
+			continue
+		}
+		var itemErr error
+		if response != nil && i < len(response.Items) {
+			for _, item := range response.Items[i] {
+				if item.Error != nil {
+					itemErr = fmt.Errorf("%s: %s", item.Error.Type, item.Error.Reason)
+				}
+			}
+		}
+		done <- itemErr
+	}
+}
+
+func (e *Store) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: e.InsecureSkipVerify}
+
+	if e.CACert != "" {
+		pem, err := os.ReadFile(e.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("read ca cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("parse ca cert: %s", e.CACert)
+		}
+		config.RootCAs = pool
+	}
+
+	if e.ClientCert != "" && e.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(e.ClientCert, e.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load client cert: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
 func (e *Store) Close(ctx context.Context) error {
+	e.Stop()
+	if e.bulkProcessor != nil {
+		if err := e.bulkProcessor.Flush(); err != nil {
+			e.logger.Error("flush-bulk-processor-failed", err)
+		}
+		if err := e.bulkProcessor.Stop(); err != nil {
+			e.logger.Error("stop-bulk-processor-failed", err)
+		}
+	}
 	e.client.Stop()
 	return nil
 }
@@ -135,6 +496,81 @@ func (e *Store) createIndexIfNotExists(ctx context.Context, name string, body st
 	return nil
 }
 
+// ensureAlias adds index to alias unless it's already a member. The
+// existence check is scoped to this index (not "does alias exist anywhere"),
+// since an alias spans multiple indices across rollovers and a check against
+// the alias name alone would short-circuit before a newly rolled-over index
+// is ever added.
+func (e *Store) ensureAlias(ctx context.Context, index string, alias string, isWriteIndex bool) error {
+	exists, err := e.client.AliasExists(alias).Index(index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("check alias exists: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	action := elastic.NewAliasAddAction(alias).Index(index)
+	if isWriteIndex {
+		action = action.IsWriteIndex(true)
+	}
+	_, err = e.client.Alias().Action(action).Do(ctx)
+	return err
+}
+
+// RolloverConditions configures the criteria under which Rollover will
+// repoint the write alias at a new concrete index.
+type RolloverConditions struct {
+	MaxAge  time.Duration
+	MaxDocs int64
+	MaxSize string
+}
+
+// Rollover atomically points the write alias at a new index once any of the
+// given conditions are met, so that retention and mapping evolution can be
+// managed without losing events mid-write. It returns whether the rollover
+// actually occurred. This relies on the write alias having been created with
+// is_write_index: true (see ensureAlias in Setup): the rollover API uses
+// that flag to know which index to retire and flips it to the new index
+// atomically, which keeps working once the alias spans many indices.
+func (e *Store) Rollover(ctx context.Context, conditions RolloverConditions) (bool, error) {
+	body := map[string]interface{}{}
+	if conditions.MaxAge > 0 {
+		body["max_age"] = conditions.MaxAge.String()
+	}
+	if conditions.MaxDocs > 0 {
+		body["max_docs"] = conditions.MaxDocs
+	}
+	if conditions.MaxSize != "" {
+		body["max_size"] = conditions.MaxSize
+	}
+
+	result, err := e.client.RolloverIndex(writeAliasName).Conditions(body).Do(ctx)
+	if err != nil {
+		e.logger.Error("rollover-index-failed", err, lager.Data{"alias": writeAliasName})
+		return false, fmt.Errorf("rollover index: %w", err)
+	}
+
+	if result.RolledOver {
+		if err := e.ensureAlias(ctx, result.NewIndex, readAliasName, false); err != nil {
+			e.logger.Error("ensure-read-alias-after-rollover-failed", err, lager.Data{"index": result.NewIndex})
+			return true, fmt.Errorf("ensure read alias after rollover: %w", err)
+		}
+	}
+
+	return result.RolledOver, nil
+}
+
+// Reindex copies documents from one concrete index into another, for use
+// when migrating events across a mapping change.
+func (e *Store) Reindex(ctx context.Context, from string, to string) error {
+	_, err := e.client.Reindex().SourceIndex(from).DestinationIndex(to).Do(ctx)
+	if err != nil {
+		e.logger.Error("reindex-failed", err, lager.Data{"from": from, "to": to})
+		return fmt.Errorf("reindex: %w", err)
+	}
+	return nil
+}
+
 func isAlreadyExists(err error) bool {
 	elasticErr, ok := err.(*elastic.Error)
 	if !ok {
@@ -143,20 +579,82 @@ func isAlreadyExists(err error) bool {
 	return elasticErr.Status == http.StatusBadRequest && elasticErr.Details.Type == "index_already_exists_exception"
 }
 
+// isPitExpiredErr reports whether err is the "search_context_missing"
+// response Elasticsearch returns when a PIT's KeepAlive has elapsed.
+func isPitExpiredErr(err error) bool {
+	elasticErr, ok := err.(*elastic.Error)
+	if !ok {
+		return false
+	}
+	return elasticErr.Status == http.StatusNotFound && elasticErr.Details.Type == "search_context_missing_exception"
+}
+
 func (e *Store) Initialize(ctx context.Context, build db.Build) error {
 	return nil
 }
 
 func (e *Store) Finalize(ctx context.Context, build db.Build) error {
+	pitID := e.forgetPit(build.ID())
+	if pitID == "" {
+		return nil
+	}
+
+	_, err := e.client.ClosePointInTime().Id(pitID).Do(ctx)
+	if err != nil {
+		e.logger.Error("close-point-in-time-failed", err, lager.Data{"build_id": build.ID()})
+		return fmt.Errorf("close point in time: %w", err)
+	}
 	return nil
 }
 
+func (e *Store) rememberPit(buildID int, pitID string) {
+	e.openPitsMu.Lock()
+	defer e.openPitsMu.Unlock()
+	if e.openPits == nil {
+		e.openPits = make(map[int]pitEntry)
+	}
+	e.openPits[buildID] = pitEntry{id: pitID, opened: time.Now()}
+}
+
+func (e *Store) forgetPit(buildID int) string {
+	e.openPitsMu.Lock()
+	defer e.openPitsMu.Unlock()
+	pitID := e.openPits[buildID].id
+	delete(e.openPits, buildID)
+	return pitID
+}
+
+// sweepStalePits closes and forgets any PIT that no Get has used for longer
+// than pitIdleTTL, reclaiming server-side resources for builds whose event
+// stream was abandoned without a Finalize call.
+func (e *Store) sweepStalePits(ctx context.Context) {
+	e.openPitsMu.Lock()
+	var stale []pitEntry
+	now := time.Now()
+	for buildID, entry := range e.openPits {
+		if now.Sub(entry.opened) > pitIdleTTL {
+			stale = append(stale, entry)
+			delete(e.openPits, buildID)
+		}
+	}
+	e.openPitsMu.Unlock()
+
+	for _, entry := range stale {
+		if _, err := e.client.ClosePointInTime().Id(entry.id).Do(ctx); err != nil {
+			e.logger.Error("close-stale-point-in-time-failed", err, lager.Data{"pit_id": entry.id})
+		}
+	}
+}
+
 func (e *Store) Put(ctx context.Context, build db.Build, events []atc.Event) (db.EventKey, error) {
 	if len(events) == 0 {
 		return nil, nil
 	}
-	bulkRequest := e.client.Bulk()
+	if !e.IsAvailable() {
+		return nil, ErrStoreUnavailable
+	}
 	var doc eventDoc
+	dones := make([]chan error, 0, len(events))
 	for _, evt := range events {
 		payload, err := json.Marshal(evt)
 		if err != nil {
@@ -178,52 +676,126 @@ func (e *Store) Put(ctx context.Context, build db.Build, events []atc.Event) (db
 			Data:         &data,
 			Tiebreak:     atomic.AddInt64(&e.counter, 1),
 		}
-		bulkRequest = bulkRequest.Add(
-			elastic.NewBulkIndexRequest().
-				Index(indexPatternPrefix).
-				Doc(doc),
-		)
+		req := elastic.NewBulkIndexRequest().
+			Index(writeAliasName).
+			Id(fmt.Sprintf("%d-%d", build.ID(), doc.Tiebreak)).
+			Doc(doc)
+
+		done := make(chan error, 1)
+		e.registerPending(req, done)
+		dones = append(dones, done)
+
+		e.bulkProcessor.Add(req)
 	}
-	_, err := bulkRequest.Do(ctx)
-	if err != nil {
-		e.logger.Error("bulk-put-failed", err)
-		return nil, fmt.Errorf("bulk put: %w", err)
+
+	// Wait for the bulk processor's After callback to resolve every request
+	// enqueued above, so a failed write is reported back to the caller
+	// instead of silently diverging from the cursor we're about to return.
+	// BulkActions/BulkSize/FlushInterval still govern when the processor
+	// actually sends the batch, so concurrent builds' events keep coalescing
+	// into shared bulk requests rather than one round-trip per Put.
+	for _, done := range dones {
+		select {
+		case err := <-done:
+			if err != nil {
+				return nil, fmt.Errorf("index event: %w", err)
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 
 	var target struct {
 		Time int64 `json:"time"`
 	}
-	if err = json.Unmarshal(*doc.Data, &target); err != nil {
+	if err := json.Unmarshal(*doc.Data, &target); err != nil {
 		return nil, err
 	}
 
 	return Key{TimeMillis: target.Time * 1000, Tiebreak: doc.Tiebreak}, nil
 }
 
+func (e *Store) openPIT(ctx context.Context, keepAlive time.Duration) (string, error) {
+	pitResult, err := e.client.OpenPointInTime(readAliasName).KeepAlive(keepAlive.String()).Do(ctx)
+	if err != nil {
+		e.logger.Error("open-point-in-time-failed", err, lager.Data{"alias": readAliasName})
+		e.markUnavailableOnConnError(err)
+		return "", fmt.Errorf("open point in time: %w", err)
+	}
+	return pitResult.Id, nil
+}
+
+// searchWithSource runs source against the PIT identified by pitID, or
+// against the read alias directly when pitID is empty (pre-7.10 clusters).
+func (e *Store) searchWithSource(ctx context.Context, source *elastic.SearchSource, pitID string, keepAlive time.Duration) (*elastic.SearchResult, error) {
+	if pitID == "" {
+		return e.client.Search(readAliasName).SearchSource(source).Do(ctx)
+	}
+	source = source.PointInTime(elastic.NewPointInTimeWithKeepAlive(pitID, keepAlive.String()))
+	return e.client.Search().SearchSource(source).Do(ctx)
+}
+
 func (e *Store) Get(ctx context.Context, build db.Build, requested int, cursor *db.EventKey) ([]event.Envelope, error) {
+	if !e.IsAvailable() {
+		return nil, ErrStoreUnavailable
+	}
+
 	offset, err := e.offset(cursor)
 	if err != nil {
 		e.logger.Error("offset-failed", err)
 		return nil, err
 	}
 
-	req := e.client.Search(indexPatternPrefix).
+	source := elastic.NewSearchSource().
 		Query(elastic.NewTermQuery("build_id", build.ID())).
 		Sort("data.time", true).
 		Sort("tiebreak", true).
 		Size(requested)
 	if offset.TimeMillis > 0 {
-		req = req.SearchAfter(offset.TimeMillis, offset.Tiebreak)
+		source = source.SearchAfter(offset.TimeMillis, offset.Tiebreak)
+	}
+
+	keepAlive := offset.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultPitKeepAlive
 	}
+	pitID := offset.PitID
 
-	searchResult, err := req.Do(ctx)
+	usingPit := e.pitSupported.Load()
+	if usingPit && pitID == "" {
+		pitID, err = e.openPIT(ctx, keepAlive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	searchResult, err := e.searchWithSource(ctx, source, pitID, keepAlive)
+	if usingPit && isPitExpiredErr(err) {
+		// The PIT outlived its KeepAlive (common when a cursor is persisted
+		// and a stream resumes well after the previous PIT expired).
+		// Transparently reopen one and retry rather than surfacing
+		// search_context_missing to the caller.
+		e.logger.Info("pit-expired-reopening", lager.Data{"build_id": build.ID()})
+		pitID, err = e.openPIT(ctx, keepAlive)
+		if err != nil {
+			return nil, err
+		}
+		searchResult, err = e.searchWithSource(ctx, source, pitID, keepAlive)
+	}
 	if err != nil {
 		e.logger.Error("search-failed", err)
+		e.markUnavailableOnConnError(err)
 		return nil, fmt.Errorf("perform search: %w", err)
 	}
+	if usingPit {
+		e.rememberPit(build.ID(), pitID)
+	}
 
 	numHits := len(searchResult.Hits.Hits)
 	if numHits == 0 {
+		if pitID != "" {
+			*cursor = Key{TimeMillis: offset.TimeMillis, Tiebreak: offset.Tiebreak, PitID: pitID, KeepAlive: keepAlive}
+		}
 		return []event.Envelope{}, nil
 	}
 	events := make([]event.Envelope, numHits)
@@ -250,11 +822,171 @@ func (e *Store) Get(ctx context.Context, build db.Build, requested int, cursor *
 	*cursor = Key{
 		TimeMillis: target.Data.Time * 1000,
 		Tiebreak:   target.Tiebreak,
+		PitID:      pitID,
+		KeepAlive:  keepAlive,
 	}
 
 	return events, nil
 }
 
+// SearchRequest describes structured filters for a cross-build event search.
+// Zero-valued fields are not applied as filters.
+type SearchRequest struct {
+	TeamIDs     []int
+	PipelineIDs []int
+	JobNames    []string
+	EventTypes  []atc.EventType
+	From        time.Time
+	To          time.Time
+	Query       string
+
+	Size int
+}
+
+// SearchResult holds the matching events along with aggregations useful for
+// summarizing them, e.g. for a UI showing "failed task events across
+// pipeline X in the last 24h".
+type SearchResult struct {
+	Events          []event.Envelope
+	EventTypeCounts map[atc.EventType]int64
+	JobCounts       map[string]int64
+	EventsOverTime  []TimeBucket
+}
+
+// TimeBucket is one point in a date histogram aggregation.
+type TimeBucket struct {
+	TimeMillis int64
+	Count      int64
+}
+
+// Search runs a structured query across all teams/pipelines, rather than
+// being scoped to a single build like Get.
+func (e *Store) Search(ctx context.Context, req SearchRequest) (SearchResult, error) {
+	if !e.IsAvailable() {
+		return SearchResult{}, ErrStoreUnavailable
+	}
+
+	query := buildSearchQuery(req)
+
+	size := req.Size
+	if size <= 0 {
+		size = 100
+	}
+
+	searchResult, err := e.client.Search(readAliasName).
+		Query(query).
+		Sort("data.time", true).
+		Sort("tiebreak", true).
+		Size(size).
+		Aggregation("event_types", elastic.NewTermsAggregation().Field("event")).
+		Aggregation("by_job", elastic.NewTermsAggregation().Field("job_name")).
+		Aggregation("over_time", elastic.NewDateHistogramAggregation().Field("data.time").CalendarInterval("hour")).
+		Do(ctx)
+	if err != nil {
+		e.logger.Error("search-query-failed", err)
+		e.markUnavailableOnConnError(err)
+		return SearchResult{}, fmt.Errorf("perform search: %w", err)
+	}
+
+	result := SearchResult{
+		Events:          make([]event.Envelope, len(searchResult.Hits.Hits)),
+		EventTypeCounts: map[atc.EventType]int64{},
+		JobCounts:       map[string]int64{},
+	}
+	for i, hit := range searchResult.Hits.Hits {
+		if err := json.Unmarshal(hit.Source, &result.Events[i]); err != nil {
+			e.logger.Error("unmarshal-hit-failed", err)
+			return SearchResult{}, fmt.Errorf("unmarshal source to event.Envelope: %w", err)
+		}
+	}
+
+	if agg, found := searchResult.Aggregations.Terms("event_types"); found {
+		for _, bucket := range agg.Buckets {
+			if key, ok := bucket.Key.(string); ok {
+				result.EventTypeCounts[atc.EventType(key)] = bucket.DocCount
+			}
+		}
+	}
+	if agg, found := searchResult.Aggregations.Terms("by_job"); found {
+		for _, bucket := range agg.Buckets {
+			if key, ok := bucket.Key.(string); ok {
+				result.JobCounts[key] = bucket.DocCount
+			}
+		}
+	}
+	if agg, found := searchResult.Aggregations.DateHistogram("over_time"); found {
+		for _, bucket := range agg.Buckets {
+			result.EventsOverTime = append(result.EventsOverTime, TimeBucket{
+				TimeMillis: int64(bucket.Key),
+				Count:      bucket.DocCount,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// buildSearchQuery translates a SearchRequest's structured filters into a
+// compound bool query. Split out from Search so the query-building logic can
+// be unit tested without a live cluster.
+func buildSearchQuery(req SearchRequest) *elastic.BoolQuery {
+	query := elastic.NewBoolQuery()
+	if len(req.TeamIDs) > 0 {
+		query = query.Filter(elastic.NewTermsQuery("team_id", intsToInterfaces(req.TeamIDs)...))
+	}
+	if len(req.PipelineIDs) > 0 {
+		query = query.Filter(elastic.NewTermsQuery("pipeline_id", intsToInterfaces(req.PipelineIDs)...))
+	}
+	if len(req.JobNames) > 0 {
+		query = query.Filter(elastic.NewTermsQuery("job_name", stringsToInterfaces(req.JobNames)...))
+	}
+	if len(req.EventTypes) > 0 {
+		query = query.Filter(elastic.NewTermsQuery("event", eventTypesToInterfaces(req.EventTypes)...))
+	}
+	if !req.From.IsZero() || !req.To.IsZero() {
+		rangeQuery := elastic.NewRangeQuery("data.time")
+		if !req.From.IsZero() {
+			rangeQuery = rangeQuery.Gte(req.From.Unix())
+		}
+		if !req.To.IsZero() {
+			rangeQuery = rangeQuery.Lte(req.To.Unix())
+		}
+		query = query.Filter(rangeQuery)
+	}
+	if req.Query != "" {
+		// Free text lives under different fields depending on event type
+		// (a Log event's text is data.payload, an Error event's is
+		// data.message), so match across both rather than a single field
+		// that only some event types actually populate.
+		query = query.Must(elastic.NewMultiMatchQuery(req.Query, "data.payload", "data.message"))
+	}
+	return query
+}
+
+func intsToInterfaces(ints []int) []interface{} {
+	values := make([]interface{}, len(ints))
+	for i, v := range ints {
+		values[i] = v
+	}
+	return values
+}
+
+func stringsToInterfaces(strs []string) []interface{} {
+	values := make([]interface{}, len(strs))
+	for i, v := range strs {
+		values[i] = v
+	}
+	return values
+}
+
+func eventTypesToInterfaces(types []atc.EventType) []interface{} {
+	values := make([]interface{}, len(types))
+	for i, v := range types {
+		values[i] = v
+	}
+	return values
+}
+
 func (e *Store) offset(cursor *db.EventKey) (Key, error) {
 	if cursor == nil || *cursor == nil {
 		return Key{}, nil
@@ -298,9 +1030,13 @@ func (e *Store) DeleteTeam(ctx context.Context, team db.Team) error {
 }
 
 func (e *Store) asyncDelete(ctx context.Context, query elastic.Query) error {
-	_, err := e.client.DeleteByQuery(indexPatternPrefix).
+	if !e.IsAvailable() {
+		return ErrStoreUnavailable
+	}
+	_, err := e.client.DeleteByQuery(readAliasName).
 		Query(query).
 		DoAsync(ctx)
+	e.markUnavailableOnConnError(err)
 	return err
 }
 
@@ -0,0 +1,155 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/olivere/elastic/v7"
+)
+
+func TestSupportsPIT(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    bool
+	}{
+		{"7.10.0", true},
+		{"7.10.2", true},
+		{"7.17.9", true},
+		{"8.1.0", true},
+		{"7.9.3", false},
+		{"6.8.0", false},
+		{"not-a-version", false},
+	} {
+		if got := supportsPIT(tc.version); got != tc.want {
+			t.Errorf("supportsPIT(%q) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestMarkUnavailableOnConnError(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		err           error
+		wantAvailable bool
+	}{
+		{"nil error", nil, true},
+		{"context canceled", context.Canceled, true},
+		{"context deadline exceeded", context.DeadlineExceeded, true},
+		{"cluster answered with an error", &elastic.Error{Status: http.StatusBadRequest}, true},
+		{"connection error", &url.Error{Op: "Get", URL: "http://es.local", Err: errors.New("connection refused")}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &Store{}
+			e.available.Store(true)
+			e.markUnavailableOnConnError(tc.err)
+			if got := e.IsAvailable(); got != tc.wantAvailable {
+				t.Errorf("IsAvailable() = %v, want %v", got, tc.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestBulkTunableDefaults(t *testing.T) {
+	e := &Store{}
+	if got := e.bulkActions(); got != 1000 {
+		t.Errorf("bulkActions() = %d, want 1000", got)
+	}
+	if got := e.bulkSize(); got != 5*1024*1024 {
+		t.Errorf("bulkSize() = %d, want %d", got, 5*1024*1024)
+	}
+	if got := e.bulkFlushInterval(); got != time.Second {
+		t.Errorf("bulkFlushInterval() = %v, want %v", got, time.Second)
+	}
+	if got := e.bulkWorkers(); got != 1 {
+		t.Errorf("bulkWorkers() = %d, want 1", got)
+	}
+
+	e = &Store{BulkActions: 50, BulkSize: 1024, BulkFlushInterval: 5 * time.Second, BulkWorkers: 4}
+	if got := e.bulkActions(); got != 50 {
+		t.Errorf("bulkActions() = %d, want 50", got)
+	}
+	if got := e.bulkSize(); got != 1024 {
+		t.Errorf("bulkSize() = %d, want 1024", got)
+	}
+	if got := e.bulkFlushInterval(); got != 5*time.Second {
+		t.Errorf("bulkFlushInterval() = %v, want %v", got, 5*time.Second)
+	}
+	if got := e.bulkWorkers(); got != 4 {
+		t.Errorf("bulkWorkers() = %d, want 4", got)
+	}
+}
+
+func TestIsAlreadyExists(t *testing.T) {
+	if isAlreadyExists(nil) {
+		t.Error("isAlreadyExists(nil) = true, want false")
+	}
+	if isAlreadyExists(&elastic.Error{Status: http.StatusNotFound, Details: &elastic.ErrorDetails{Type: "index_already_exists_exception"}}) {
+		t.Error("isAlreadyExists with wrong status = true, want false")
+	}
+	if !isAlreadyExists(&elastic.Error{Status: http.StatusBadRequest, Details: &elastic.ErrorDetails{Type: "index_already_exists_exception"}}) {
+		t.Error("isAlreadyExists with matching status/type = false, want true")
+	}
+}
+
+func TestIsPitExpiredErr(t *testing.T) {
+	if isPitExpiredErr(nil) {
+		t.Error("isPitExpiredErr(nil) = true, want false")
+	}
+	if isPitExpiredErr(&elastic.Error{Status: http.StatusBadRequest, Details: &elastic.ErrorDetails{Type: "search_context_missing_exception"}}) {
+		t.Error("isPitExpiredErr with wrong status = true, want false")
+	}
+	if !isPitExpiredErr(&elastic.Error{Status: http.StatusNotFound, Details: &elastic.ErrorDetails{Type: "search_context_missing_exception"}}) {
+		t.Error("isPitExpiredErr with matching status/type = false, want true")
+	}
+}
+
+func TestBuildSearchQuery(t *testing.T) {
+	req := SearchRequest{
+		TeamIDs:     []int{1, 2},
+		PipelineIDs: []int{3},
+		JobNames:    []string{"build"},
+		EventTypes:  []atc.EventType{"log"},
+		From:        time.Unix(1000, 0),
+		To:          time.Unix(2000, 0),
+		Query:       "failed",
+	}
+
+	source, err := buildSearchQuery(req).Source()
+	if err != nil {
+		t.Fatalf("Source() returned error: %s", err)
+	}
+	bq, ok := source.(map[string]interface{})["bool"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a bool query, got %#v", source)
+	}
+
+	filters, ok := bq["filter"].([]interface{})
+	if !ok || len(filters) != 5 {
+		t.Fatalf("expected 5 filter clauses (team_id, pipeline_id, job_name, event, data.time range), got %#v", bq["filter"])
+	}
+	if _, ok := bq["must"]; !ok {
+		t.Error("expected a must clause for the free-text query, got none")
+	}
+}
+
+func TestBuildSearchQuery_NoFilters(t *testing.T) {
+	source, err := buildSearchQuery(SearchRequest{}).Source()
+	if err != nil {
+		t.Fatalf("Source() returned error: %s", err)
+	}
+	bq, ok := source.(map[string]interface{})["bool"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a bool query, got %#v", source)
+	}
+	if _, ok := bq["filter"]; ok {
+		t.Errorf("expected no filter clauses for a zero-valued SearchRequest, got %#v", bq["filter"])
+	}
+	if _, ok := bq["must"]; ok {
+		t.Errorf("expected no must clause for a zero-valued SearchRequest, got %#v", bq["must"])
+	}
+}